@@ -0,0 +1,210 @@
+// Package highlight classifies the tokens of Internet Object documents
+// into semantic classes for the editors and syntax highlighters.
+package highlight
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/maniartech/InternetObject-go/parsers"
+)
+
+// Class represents the semantic class of a token
+type Class uint32
+
+const (
+	// Key represents the keys of the object members
+	Key Class = iota
+
+	// String represents the string values including the annotated strings
+	String
+
+	// Number represents the number values
+	Number
+
+	// Boolean represents the true and false literals
+	Boolean
+
+	// Null represents the null literal
+	Null
+
+	// SectionName represents the name following the --- section separator
+	SectionName
+
+	// SchemaRef represents the $ prefixed schema references
+	SchemaRef
+
+	// Comment represents the # line comments
+	Comment
+
+	// Error represents the span of a syntax error
+	Error
+)
+
+// Legend represents the LSP semantic token types indexed by Class, to be
+// sent as the tokenTypes of the server's semantic tokens legend.
+var /* const */ Legend = []string{
+	"property",
+	"string",
+	"number",
+	"boolean",
+	"null",
+	"namespace",
+	"type",
+	"comment",
+	"error",
+}
+
+func (c Class) String() string {
+	if int(c) < len(Legend) {
+		return Legend[c]
+	}
+	return "unknown"
+}
+
+// Token represents a classified span of the input
+type Token struct {
+	Class Class
+	Text  string
+	Start int
+	End   int
+	Row   int
+	Col   int
+}
+
+// Classify reads the tokens of the input including the comments and
+// returns the classified ones. The separators are not classified. When the
+// input has a syntax error, the classification stops with an Error token
+// spanning the error.
+func Classify(input string) []Token {
+	l := parsers.NewLexer(input)
+	l.KeepComments = true
+
+	tokens := make([]*parsers.Token, 0)
+	it := l.Iter()
+	for it.Next() {
+		tokens = append(tokens, it.Token())
+	}
+
+	result := make([]Token, 0, len(tokens))
+	for i, token := range tokens {
+		class, ok := classOf(tokens, i)
+		if !ok {
+			continue
+		}
+		result = append(result, Token{
+			Class: class,
+			Text:  token.Text,
+			Start: token.Start,
+			End:   token.End,
+			Row:   token.Row,
+			Col:   token.Col,
+		})
+	}
+
+	var syntaxErr *parsers.SyntaxError
+	if errors.As(it.Err(), &syntaxErr) {
+		result = append(result, Token{
+			Class: Error,
+			Text:  string([]rune(input)[syntaxErr.Start : syntaxErr.End+1]),
+			Start: syntaxErr.Start,
+			End:   syntaxErr.End,
+			Row:   syntaxErr.Row,
+			Col:   syntaxErr.Col,
+		})
+	}
+
+	return result
+}
+
+// classOf returns the class of the token at the specified index, using
+// the neighbouring tokens to tell the keys and section names apart.
+func classOf(tokens []*parsers.Token, i int) (Class, bool) {
+	token := tokens[i]
+
+	switch token.Type {
+	case parsers.TypeNumber:
+		return Number, true
+	case parsers.TypeBool:
+		return Boolean, true
+	case parsers.TypeNull:
+		return Null, true
+	case parsers.TypeComment:
+		return Comment, true
+	case parsers.TypeDuration, parsers.TypeUUID, parsers.TypeAnnotation,
+		parsers.TypeUnknownAnnotation:
+		return String, true
+	case parsers.TypeString, parsers.TypeRawString:
+	default:
+		return 0, false
+	}
+
+	if token.Type == parsers.TypeString && strings.HasPrefix(token.Text, "$") {
+		return SchemaRef, true
+	}
+
+	if i > 0 && tokens[i-1].Type == parsers.TypeDatasep && tokens[i-1].Row == token.Row {
+		return SectionName, true
+	}
+
+	if i+1 < len(tokens) && tokens[i+1].Type == parsers.TypeSeparator &&
+		tokens[i+1].Text == string(parsers.Colon) {
+		return Key, true
+	}
+
+	return String, true
+}
+
+// Encode returns the tokens in the LSP semantic tokens data format: five
+// integers per token holding the line delta, the start char delta, the
+// length, the token type (Class) and the modifiers (always 0). The lines
+// are zero based and the chars are counted in UTF-16 code units as the
+// LSP requires. The tokens spanning multiple lines are clipped at the end
+// of their first line.
+func Encode(input string, tokens []Token) []uint32 {
+	text := []rune(input)
+
+	// The UTF-16 column of each rune within its line
+	cols := make([]int, len(text)+1)
+	col := 0
+	for i, ch := range text {
+		cols[i] = col
+		col += utf16Len(ch)
+		if ch == parsers.NewLine {
+			col = 0
+		}
+	}
+	cols[len(text)] = col
+
+	data := make([]uint32, 0, len(tokens)*5)
+	prevLine, prevChar := 0, 0
+	for _, token := range tokens {
+		line := token.Row - 1
+		char := cols[token.Start]
+
+		length := 0
+		for i := token.Start; i <= token.End && i < len(text) && text[i] != parsers.NewLine; i++ {
+			length += utf16Len(text[i])
+		}
+
+		deltaChar := char
+		if line == prevLine {
+			deltaChar = char - prevChar
+		}
+
+		data = append(data, uint32(line-prevLine), uint32(deltaChar),
+			uint32(length), uint32(token.Class), 0)
+		prevLine, prevChar = line, char
+	}
+
+	return data
+}
+
+// utf16Len returns the number of UTF-16 code units encoding the rune, the
+// runes beyond the basic multilingual plane take a surrogate pair.
+func utf16Len(ch rune) int {
+	if ch >= 0x10000 {
+		return 2
+	}
+	return 1
+}
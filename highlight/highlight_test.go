@@ -0,0 +1,64 @@
+package highlight
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	input := "# users\n--- users: $user\n{name: 'Jo', age: 25, active: T, tag: N}"
+
+	expected := []struct {
+		class Class
+		text  string
+	}{
+		{Comment, "# users"},
+		{SectionName, "users"},
+		{SchemaRef, "$user"},
+		{Key, "name"}, {String, "'Jo'"},
+		{Key, "age"}, {Number, "25"},
+		{Key, "active"}, {Boolean, "T"},
+		{Key, "tag"}, {Null, "N"},
+	}
+
+	tokens := Classify(input)
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, found %d: %+v", len(expected), len(tokens), tokens)
+	}
+	for i, exp := range expected {
+		if tokens[i].Class != exp.class || tokens[i].Text != exp.text {
+			t.Errorf("token %d: expected %s %q, found %s %q",
+				i, exp.class, exp.text, tokens[i].Class, tokens[i].Text)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tokens := Classify("a, 1e")
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, found %+v", tokens)
+	}
+	if last := tokens[1]; last.Class != Error || last.Text != "1e" {
+		t.Errorf("unexpected error token %+v", last)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	input := "a: \"\U0001F600\", b: 1,\n  c: T"
+
+	data := Encode(input, Classify(input))
+	expected := []uint32{
+		0, 0, 1, uint32(Key), 0,
+		0, 3, 4, uint32(String), 0,
+		0, 6, 1, uint32(Key), 0,
+		0, 3, 1, uint32(Number), 0,
+		1, 2, 1, uint32(Key), 0,
+		0, 3, 1, uint32(Boolean), 0,
+	}
+
+	if len(data) != len(expected) {
+		t.Fatalf("expected %v, found %v", expected, data)
+	}
+	for i := range expected {
+		if data[i] != expected[i] {
+			t.Fatalf("expected %v, found %v", expected, data)
+		}
+	}
+}
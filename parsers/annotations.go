@@ -0,0 +1,207 @@
+package parsers
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AnnotationParser converts the content of an annotated string to its value
+type AnnotationParser func(content string) (interface{}, error)
+
+// AnnotationFormatter converts the value of an annotated string back to
+// its content
+type AnnotationFormatter func(v interface{}) (string, error)
+
+// annotation represents the type of an annotated string such as du"1h30m"
+type annotation struct {
+	tokenType string
+	parse     AnnotationParser
+	format    AnnotationFormatter
+}
+
+// annotations represents the supported annotations mapped by their names
+var annotations = map[string]annotation{
+	"du": {TypeDuration, parseDuration, formatDuration},
+	"u":  {TypeUUID, parseUUID, formatUUID},
+}
+
+// annotationsMutex guards the annotations against the concurrent
+// registrations
+var annotationsMutex sync.RWMutex
+
+// RegisterAnnotation registers the custom annotation of the specified name
+// so that its annotated strings such as geo"48.2,16.3" are read into the
+// TypeAnnotation tokens holding the value returned by the parse function.
+// The format function converts the values back to the content and is used
+// by FormatAnnotation. The name must consist of lowercase letters and must
+// not be registered already.
+func RegisterAnnotation(name string, parse AnnotationParser, format AnnotationFormatter) error {
+	if name == "" || strings.TrimLeft(name, "abcdefghijklmnopqrstuvwxyz") != "" {
+		return errors.New("invalid annotation name " + strconv.Quote(name))
+	}
+	if parse == nil || format == nil {
+		return errors.New("the parse and format functions are required")
+	}
+
+	annotationsMutex.Lock()
+	defer annotationsMutex.Unlock()
+
+	if _, ok := annotations[name]; ok {
+		return errors.New("annotation " + strconv.Quote(name) + " is already registered")
+	}
+	annotations[name] = annotation{TypeAnnotation, parse, format}
+	return nil
+}
+
+// FormatAnnotation returns the annotated string of the specified value
+// such as du"1h30m0s", using the formatter of the named annotation.
+func FormatAnnotation(name string, v interface{}) (string, error) {
+	ann, ok := lookupAnnotation(name)
+	if !ok {
+		return "", errors.New("unsupported annotation " + strconv.Quote(name))
+	}
+
+	content, err := ann.format(v)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsRune(content, DoubleQuote) {
+		return "", errors.New("the annotation content must not contain quotes")
+	}
+	return name + string(DoubleQuote) + content + string(DoubleQuote), nil
+}
+
+func lookupAnnotation(name string) (annotation, bool) {
+	annotationsMutex.RLock()
+	defer annotationsMutex.RUnlock()
+
+	ann, ok := annotations[name]
+	return ann, ok
+}
+
+// UnknownAnnotation represents the value of an annotated string whose
+// annotation is not supported, kept as is in the LenientAnnotations mode.
+type UnknownAnnotation struct {
+	Name    string
+	Content string
+}
+
+// UUID represents the 16 bytes value of the u"..." annotated strings
+type UUID [16]byte
+
+// String returns the canonical xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// annotationName returns the name of the annotation starting at the
+// current char, or an empty string when there is none.
+func annotationName(l *lexer) string {
+	end := l.index
+	for end < len(l.text) && l.text[end] >= 'a' && l.text[end] <= 'z' {
+		end++
+	}
+
+	if end == l.index || end >= len(l.text) || l.text[end] != DoubleQuote {
+		return ""
+	}
+
+	return string(l.text[l.index:end])
+}
+
+// scanAnnotated scans the annotated string starting at the current char
+// and converts its content using the annotation of the specified name.
+// The unknown annotations are reported as errors unless the lexer is in
+// the LenientAnnotations mode.
+func (l *lexer) scanAnnotated(name string) (*Token, error) {
+	start, row, col := l.index, l.row, l.col
+	l.advance(len(name))
+
+	token, err := l.scan(TypeString, stringScanner, true)
+	if err != nil {
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			syntaxErr.Start, syntaxErr.Row, syntaxErr.Col = start, row, col
+		}
+		return nil, err
+	}
+
+	text := token.Text
+	token.Text = name + text
+	token.Start = start
+	token.Row = row
+	token.Col = col
+
+	content := text[1 : len(text)-1]
+	ann, ok := lookupAnnotation(name)
+	if !ok {
+		if !l.LenientAnnotations {
+			return nil, NewSyntaxError(ErrorUnsupportedAnnotation,
+				"unsupported annotation "+strconv.Quote(name), token)
+		}
+
+		token.Type = TypeUnknownAnnotation
+		token.Val = UnknownAnnotation{Name: name, Content: content}
+		return token, nil
+	}
+
+	val, err := ann.parse(content)
+	if err != nil {
+		return nil, NewSyntaxError(ErrorInvalidAnnotation, err.Error(), token)
+	}
+
+	token.Type = ann.tokenType
+	token.Val = val
+	return token, nil
+}
+
+// parseDuration parses the du"..." annotated strings such as du"1h30m"
+func parseDuration(content string) (interface{}, error) {
+	return time.ParseDuration(content)
+}
+
+func formatDuration(v interface{}) (string, error) {
+	d, ok := v.(time.Duration)
+	if !ok {
+		return "", errors.New("expected a time.Duration value")
+	}
+	return d.String(), nil
+}
+
+// parseUUID parses the u"..." annotated strings in the canonical
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form
+func parseUUID(content string) (interface{}, error) {
+	var u UUID
+
+	if len(content) != 36 || content[8] != '-' || content[13] != '-' ||
+		content[18] != '-' || content[23] != '-' {
+		return nil, errors.New("invalid uuid " + strconv.Quote(content))
+	}
+
+	digits := content[0:8] + content[9:13] + content[14:18] + content[19:23] + content[24:]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return nil, errors.New("invalid uuid " + strconv.Quote(content))
+	}
+	return u, nil
+}
+
+func formatUUID(v interface{}) (string, error) {
+	u, ok := v.(UUID)
+	if !ok {
+		return "", errors.New("expected a UUID value")
+	}
+	return u.String(), nil
+}
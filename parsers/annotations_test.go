@@ -0,0 +1,171 @@
+package parsers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDurationAnnotation(t *testing.T) {
+	l := NewLexer(`{timeout: du"1h30m", retry: du"250ms"}, dux`)
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	timeout := l.tokens[3]
+	if timeout.Type != TypeDuration || timeout.Val != 90*time.Minute ||
+		timeout.Text != `du"1h30m"` || timeout.Start != 10 || timeout.End != 18 || timeout.Col != 11 {
+		t.Errorf("unexpected duration token %+v", timeout)
+	}
+
+	if l.tokens[7].Val != 250*time.Millisecond {
+		t.Errorf("unexpected duration %v", l.tokens[7].Val)
+	}
+
+	// The names not followed by a quote remain open strings
+	if last := l.tokens[len(l.tokens)-1]; last.Type != TypeString || last.Text != "dux" {
+		t.Errorf("unexpected token %+v", last)
+	}
+}
+
+func TestInvalidAnnotation(t *testing.T) {
+	err := NewLexer(`a, du"1x"`).ReadAll()
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok || syntaxErr.Code != ErrorInvalidAnnotation || syntaxErr.Start != 3 || syntaxErr.End != 8 {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if err := NewLexer(`du"1h`).ReadAll(); err == nil {
+		t.Error("expected an error for the unterminated duration")
+	}
+}
+
+func TestUnknownAnnotation(t *testing.T) {
+	text := `a, geo"48.2,16.3"`
+
+	err := NewLexer(text).ReadAll()
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok || syntaxErr.Code != ErrorUnsupportedAnnotation || syntaxErr.Start != 3 {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	l := NewLexer(text)
+	l.LenientAnnotations = true
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	token := l.tokens[2]
+	expected := UnknownAnnotation{Name: "geo", Content: "48.2,16.3"}
+	if token.Type != TypeUnknownAnnotation || token.Val != expected || token.Text != `geo"48.2,16.3"` {
+		t.Errorf("unexpected token %+v", token)
+	}
+}
+
+func TestUUIDAnnotation(t *testing.T) {
+	l := NewLexer(`u"123E4567-e89b-12d3-a456-426614174000"`)
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	token := l.tokens[0]
+	u, ok := token.Val.(UUID)
+	if token.Type != TypeUUID || !ok || u[0] != 0x12 || u[15] != 0x00 {
+		t.Fatalf("unexpected uuid token %+v", token)
+	}
+	if u.String() != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("unexpected uuid string %s", u.String())
+	}
+
+	invalid := []string{
+		`u""`,
+		`u"123e4567e89b12d3a456426614174000"`,
+		`u"123e4567-e89b-12d3-a456-42661417400g"`,
+		`u"123e4567-e89b-12d3-a4567-26614174000"`,
+	}
+	for _, text := range invalid {
+		err := NewLexer(text).ReadAll()
+		if syntaxErr, ok := err.(*SyntaxError); !ok || syntaxErr.Code != ErrorInvalidAnnotation {
+			t.Errorf("%s: unexpected error %v", text, err)
+		}
+	}
+}
+
+type geo struct {
+	lat, lng float64
+}
+
+func TestRegisterAnnotation(t *testing.T) {
+	parse := func(content string) (interface{}, error) {
+		parts := strings.Split(content, ",")
+		if len(parts) != 2 {
+			return nil, errors.New("expected lat,lng")
+		}
+		lat, err1 := strconv.ParseFloat(parts[0], 64)
+		lng, err2 := strconv.ParseFloat(parts[1], 64)
+		if err1 != nil || err2 != nil {
+			return nil, errors.New("invalid coordinates")
+		}
+		return geo{lat, lng}, nil
+	}
+	format := func(v interface{}) (string, error) {
+		g := v.(geo)
+		return strconv.FormatFloat(g.lat, 'f', -1, 64) + "," + strconv.FormatFloat(g.lng, 'f', -1, 64), nil
+	}
+
+	if err := RegisterAnnotation("geo", parse, format); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		annotationsMutex.Lock()
+		delete(annotations, "geo")
+		annotationsMutex.Unlock()
+	}()
+
+	l := NewLexer(`{at: geo"48.2,16.3"}`)
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+	token := l.tokens[3]
+	if token.Type != TypeAnnotation || token.Val != (geo{48.2, 16.3}) {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	err := NewLexer(`geo"x"`).ReadAll()
+	if !errors.Is(err, ErrInvalidAnnotation) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	text, err := FormatAnnotation("geo", geo{1.5, 2})
+	if err != nil || text != `geo"1.5,2"` {
+		t.Errorf("unexpected annotated string %q (%v)", text, err)
+	}
+
+	invalid := []string{"geo", "", "Geo", "g1"}
+	for _, name := range invalid {
+		if RegisterAnnotation(name, parse, format) == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestFormatAnnotation(t *testing.T) {
+	text, err := FormatAnnotation("du", 90*time.Minute)
+	if err != nil || text != `du"1h30m0s"` {
+		t.Errorf("unexpected duration %q (%v)", text, err)
+	}
+
+	text, err = FormatAnnotation("u", UUID{0xff})
+	if err != nil || text != `u"ff000000-0000-0000-0000-000000000000"` {
+		t.Errorf("unexpected uuid %q (%v)", text, err)
+	}
+
+	if _, err := FormatAnnotation("du", "1h"); err == nil {
+		t.Error("expected an error for the value of a wrong type")
+	}
+	if _, err := FormatAnnotation("unknown", 1); err == nil {
+		t.Error("expected an error for the unknown annotation")
+	}
+}
@@ -30,6 +30,12 @@ const Datasep = "---"
 // Space represents the space character
 const Space = ' '
 
+// NBSP represents the no-break space U+00A0 character
+const NBSP = '\u00A0'
+
+// BOM represents the byte order mark U+FEFF character
+const BOM = '\uFEFF'
+
 // NewLine represents the newlne \n character
 const NewLine = '\n'
 
@@ -59,6 +65,9 @@ const TypeDatasep = "datasep"
 // TypeString represents the separator type
 const TypeString = "string"
 
+// TypeRawString represents the raw string type
+const TypeRawString = "raw-string"
+
 // TypeNumber represents the number type
 const TypeNumber = "number"
 
@@ -67,3 +76,20 @@ const TypeBool = "bool"
 
 // TypeNull represents the null type
 const TypeNull = "null"
+
+// TypeComment represents the comment type
+const TypeComment = "comment"
+
+// TypeDuration represents the duration type of du"..." annotated strings
+const TypeDuration = "duration"
+
+// TypeUUID represents the uuid type of u"..." annotated strings
+const TypeUUID = "uuid"
+
+// TypeAnnotation represents the type of the annotated strings with the
+// custom annotations registered using RegisterAnnotation
+const TypeAnnotation = "annotation"
+
+// TypeUnknownAnnotation represents the type of the annotated strings with
+// unsupported annotations
+const TypeUnknownAnnotation = "unknown-annotation"
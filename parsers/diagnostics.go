@@ -0,0 +1,60 @@
+package parsers
+
+import "fmt"
+
+// Severity represents the severity level of a Diagnostic
+type Severity int
+
+const (
+	// SeverityError represents the issues which make the text invalid
+	SeverityError Severity = iota + 1
+
+	// SeverityWarning represents the issues which are tolerated but likely
+	// to be a mistake or to lose information
+	SeverityWarning
+
+	// SeverityInfo represents the informational notes
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return "unknown"
+}
+
+// Diagnostic represents an issue found in the text, identified by its code
+// and located by the span and position it refers to.
+type Diagnostic struct {
+	Code     string
+	Severity Severity
+	Message  string
+	Start    int
+	End      int
+	Row      int
+	Col      int
+
+	// Fix is an optional suggestion to resolve the issue
+	Fix string
+}
+
+func (d Diagnostic) String() string {
+	text := fmt.Sprintf("%d:%d: %s: %s (%s)", d.Row, d.Col, d.Severity, d.Message, d.Code)
+	if d.Fix != "" {
+		text += "; " + d.Fix
+	}
+	return text
+}
+
+// fixes represents the fix suggestions mapped by the error codes
+var /* const */ fixes = map[string]string{
+	ErrorInvalidNumber:         "enclose the value in quotes to keep it as a string",
+	ErrorUnterminatedString:    "add the closing quote",
+	ErrorUnsupportedAnnotation: "register the annotation or enable LenientAnnotations",
+}
@@ -0,0 +1,80 @@
+package parsers
+
+import "testing"
+
+func TestDiagnostics(t *testing.T) {
+	l := NewLexer("a, 1e")
+	if e := l.ReadAll(); e == nil {
+		t.Fatal("expected an error")
+	}
+
+	diagnostics := l.GetDiagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, found %d", len(diagnostics))
+	}
+
+	d := diagnostics[0]
+	if d.Code != ErrorInvalidNumber || d.Severity != SeverityError ||
+		d.Start != 3 || d.End != 4 || d.Row != 1 || d.Col != 4 || d.Fix == "" {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+
+	expected := `1:4: error: malformed number "1e" (invalid-number); ` + d.Fix
+	if d.String() != expected {
+		t.Errorf("expected %q, found %q", expected, d.String())
+	}
+}
+
+func TestDiagnosticsWarning(t *testing.T) {
+	l := NewLexer("a,\n  geo\"1,2\"")
+	l.LenientAnnotations = true
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	diagnostics := l.GetDiagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].Severity != SeverityWarning ||
+		diagnostics[0].Row != 2 || diagnostics[0].Col != 3 {
+		t.Errorf("unexpected diagnostics %+v", diagnostics)
+	}
+}
+
+func TestUnterminatedString(t *testing.T) {
+	tests := []struct {
+		text     string
+		row, col int
+	}{
+		{"a,\n \"bc", 2, 2},
+		{"'abc", 1, 1},
+		{"a, du\"1h", 1, 4},
+		{"a, \"", 1, 4},
+		{"'", 1, 1},
+		{"\"abc\\\"", 1, 1},
+		{"'abc''", 1, 1},
+		{"a, du\"", 1, 4},
+	}
+
+	for _, test := range tests {
+		err := NewLexer(test.text).ReadAll()
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok || syntaxErr.Code != ErrorUnterminatedString ||
+			syntaxErr.Row != test.row || syntaxErr.Col != test.col {
+			t.Errorf("%q: unexpected error %v", test.text, err)
+		}
+	}
+}
+
+func TestCompleteStrings(t *testing.T) {
+	tests := []string{`"a\"b"`, `""`, `'a''b'`, `''`, `''''`, `'a'''`}
+
+	for _, text := range tests {
+		l := NewLexer(text + ", x")
+		if e := l.ReadAll(); e != nil {
+			t.Errorf("%s: unexpected error %v", text, e)
+			continue
+		}
+		if len(l.tokens) != 3 || l.tokens[0].Text != text {
+			t.Errorf("%s: unexpected tokens %+v", text, l.tokens)
+		}
+	}
+}
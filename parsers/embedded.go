@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EmbedOpen represents the default delimiter which opens an embedded block
+const EmbedOpen = "```io"
+
+// EmbedClose represents the default delimiter which closes an embedded block
+const EmbedClose = "```"
+
+// EmbeddedBlock represents an Internet Object document embedded in the
+// mixed text such as Markdown.
+type EmbeddedBlock struct {
+	// Text is the content of the block without the delimiter lines
+	Text string
+
+	// Line is the row of the containing text at which the content starts
+	Line int
+}
+
+// ExtractEmbedded finds all the blocks enclosed in the specified open and
+// close delimiter lines. The delimiters are matched against the whole
+// line ignoring the surrounding white spaces. An unclosed block runs till
+// the end of the text.
+func ExtractEmbedded(text, open, close string) []EmbeddedBlock {
+	blocks := make([]EmbeddedBlock, 0)
+	lines := strings.Split(text, "\n")
+
+	inside := false
+	var block EmbeddedBlock
+	var content []string
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if !inside {
+			if trimmed == open {
+				inside = true
+				block = EmbeddedBlock{Line: i + 2}
+				content = content[:0]
+			}
+			continue
+		}
+
+		if trimmed == close {
+			inside = false
+			block.Text = strings.Join(content, "\n")
+			blocks = append(blocks, block)
+			continue
+		}
+		content = append(content, line)
+	}
+
+	if inside {
+		block.Text = strings.Join(content, "\n")
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// ReadAll reads all the tokens of the block. The rows of the tokens and
+// errors are mapped to the rows of the containing text.
+func (b EmbeddedBlock) ReadAll() ([]*Token, error) {
+	l := NewLexer(b.Text)
+	err := l.ReadAll()
+
+	for _, token := range l.tokens {
+		token.Row += b.Line - 1
+	}
+
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) {
+		mapped := *syntaxErr
+		mapped.Row += b.Line - 1
+		return l.tokens, &mapped
+	}
+
+	if err != nil {
+		return l.tokens, fmt.Errorf("line %d: %s", l.row+b.Line-1, err.Error())
+	}
+	return l.tokens, nil
+}
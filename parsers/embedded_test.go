@@ -0,0 +1,55 @@
+package parsers
+
+import "testing"
+
+func TestExtractEmbedded(t *testing.T) {
+	text := "# Config\n" +
+		"\n" +
+		"```io\n" +
+		"a, b\n" +
+		"```\n" +
+		"Some text\n" +
+		"  ```io\n" +
+		"c,\n" +
+		"  d\n" +
+		"  ```\n" +
+		"```json\n" +
+		"{}\n" +
+		"```\n"
+
+	blocks := ExtractEmbedded(text, EmbedOpen, EmbedClose)
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks, found %d", len(blocks))
+	}
+
+	if blocks[0].Text != "a, b" || blocks[0].Line != 4 {
+		t.Errorf("unexpected first block %+v", blocks[0])
+	}
+
+	if blocks[1].Text != "c,\n  d" || blocks[1].Line != 8 {
+		t.Errorf("unexpected second block %+v", blocks[1])
+	}
+
+	tokens, err := blocks[1].ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := tokens[len(tokens)-1]
+	if last.Text != "d" || last.Row != 9 || last.Col != 3 {
+		t.Errorf("unexpected position of %q: %d:%d", last.Text, last.Row, last.Col)
+	}
+}
+
+func TestEmbeddedError(t *testing.T) {
+	blocks := ExtractEmbedded("text\n```io\na,\n\"b\n```", EmbedOpen, EmbedClose)
+	if len(blocks) != 1 {
+		t.Fatalf("expected 1 block, found %d", len(blocks))
+	}
+
+	_, err := blocks[0].ReadAll()
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok || syntaxErr.Code != ErrorUnterminatedString || syntaxErr.Row != 4 || syntaxErr.Col != 1 {
+		t.Errorf("unexpected error %v", err)
+	}
+}
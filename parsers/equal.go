@@ -0,0 +1,122 @@
+package parsers
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// ValueEqual returns true when the specified values are equal as per the
+// Internet Object semantics:
+//
+//   - The numbers are compared by their numeric values regardless of their
+//     Go types, so int64(25), float64(25) and big.NewInt(25) are equal.
+//   - The negative zero equals the zero.
+//   - NaN equals NaN, so that the documents containing NaN compare equal to
+//     themselves.
+//   - The arrays ([]interface{}) and objects (map[string]interface{}) are
+//     compared deeply using the same rules.
+//
+// The other values are compared using reflect.DeepEqual.
+func ValueEqual(a, b interface{}) bool {
+	if numA, ok := toNumber(a); ok {
+		numB, ok := toNumber(b)
+		return ok && numA.equal(numB)
+	}
+
+	switch a := a.(type) {
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !ValueEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, val := range a {
+			other, ok := b[key]
+			if !ok || !ValueEqual(val, other) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// number represents a numeric value either as an integer or a float
+type number struct {
+	integer *big.Int
+	float   float64
+	isFloat bool
+}
+
+// toNumber converts the numeric values of the Go types produced by the
+// parsers to a number.
+func toNumber(v interface{}) (number, bool) {
+	switch v := v.(type) {
+	case int:
+		return number{integer: big.NewInt(int64(v))}, true
+	case int8:
+		return number{integer: big.NewInt(int64(v))}, true
+	case int16:
+		return number{integer: big.NewInt(int64(v))}, true
+	case int32:
+		return number{integer: big.NewInt(int64(v))}, true
+	case int64:
+		return number{integer: big.NewInt(v)}, true
+	case uint:
+		return number{integer: new(big.Int).SetUint64(uint64(v))}, true
+	case uint8:
+		return number{integer: new(big.Int).SetUint64(uint64(v))}, true
+	case uint16:
+		return number{integer: new(big.Int).SetUint64(uint64(v))}, true
+	case uint32:
+		return number{integer: new(big.Int).SetUint64(uint64(v))}, true
+	case uint64:
+		return number{integer: new(big.Int).SetUint64(v)}, true
+	case *big.Int:
+		if v == nil {
+			return number{}, false
+		}
+		return number{integer: v}, true
+	case float32:
+		return number{float: float64(v), isFloat: true}, true
+	case float64:
+		return number{float: v, isFloat: true}, true
+	}
+	return number{}, false
+}
+
+func (n number) equal(other number) bool {
+	if !n.isFloat && !other.isFloat {
+		return n.integer.Cmp(other.integer) == 0
+	}
+
+	if n.isFloat && other.isFloat {
+		if math.IsNaN(n.float) || math.IsNaN(other.float) {
+			return math.IsNaN(n.float) && math.IsNaN(other.float)
+		}
+		return n.float == other.float
+	}
+
+	// Compare the integer with the float exactly
+	f, i := n.float, other.integer
+	if !n.isFloat {
+		f, i = other.float, n.integer
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return false
+	}
+	return new(big.Float).SetFloat64(f).Cmp(new(big.Float).SetInt(i)) == 0
+}
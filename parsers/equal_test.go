@@ -0,0 +1,49 @@
+package parsers
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestValueEqual(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	huger := new(big.Int).Add(huge, big.NewInt(1))
+
+	tests := []struct {
+		a, b     interface{}
+		expected bool
+	}{
+		{int64(25), big.NewInt(25), true},
+		{25, float64(25), true},
+		{uint8(25), int32(25), true},
+		{float64(25.5), big.NewInt(25), false},
+		{huge, huge, true},
+		{huge, huger, false},
+		{int64(math.MaxInt64), float64(math.MaxInt64), false},
+		{math.Copysign(0, -1), float64(0), true},
+		{math.Copysign(0, -1), 0, true},
+		{math.NaN(), math.NaN(), true},
+		{math.NaN(), 0, false},
+		{math.Inf(1), math.Inf(1), true},
+		{math.Inf(1), big.NewInt(1), false},
+		{"25", 25, false},
+		{nil, nil, true},
+		{nil, 0, false},
+		{true, true, true},
+		{[]interface{}{1, "a"}, []interface{}{1.0, "a"}, true},
+		{[]interface{}{1}, []interface{}{1, 2}, false},
+		{map[string]interface{}{"a": big.NewInt(1)}, map[string]interface{}{"a": 1.0}, true},
+		{map[string]interface{}{"a": 1}, map[string]interface{}{"b": 1}, false},
+		{UUID{1}, UUID{1}, true},
+	}
+
+	for _, test := range tests {
+		if result := ValueEqual(test.a, test.b); result != test.expected {
+			t.Errorf("ValueEqual(%v, %v) = %v, expected %v", test.a, test.b, result, test.expected)
+		}
+		if result := ValueEqual(test.b, test.a); result != test.expected {
+			t.Errorf("ValueEqual(%v, %v) = %v, expected %v", test.b, test.a, result, test.expected)
+		}
+	}
+}
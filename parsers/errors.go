@@ -0,0 +1,101 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorInvalidNumber represents the error code of the malformed numbers
+// such as 1e, 0x and --5
+const ErrorInvalidNumber = "invalid-number"
+
+// ErrorInvalidAnnotation represents the error code of the annotated strings
+// whose content is not valid for the annotation, such as du"1x"
+const ErrorInvalidAnnotation = "invalid-annotation"
+
+// ErrorUnsupportedAnnotation represents the error code of the annotated
+// strings whose annotation is not supported
+const ErrorUnsupportedAnnotation = "unsupported-annotation"
+
+// ErrorUnterminatedString represents the error code of the strings which
+// are not closed till the end of the text
+const ErrorUnterminatedString = "unterminated-string"
+
+// ErrUnexpectedEOF is reported when the text ends in the middle of a token
+var ErrUnexpectedEOF = errors.New("unexpected end of text")
+
+// ErrInvalidNumber is the sentinel of the ErrorInvalidNumber errors
+var ErrInvalidNumber = errors.New("invalid number")
+
+// ErrInvalidAnnotation is the sentinel of the ErrorInvalidAnnotation errors
+var ErrInvalidAnnotation = errors.New("invalid annotation")
+
+// ErrUnsupportedAnnotation is the sentinel of the ErrorUnsupportedAnnotation
+// errors
+var ErrUnsupportedAnnotation = errors.New("unsupported annotation")
+
+// ErrUnterminatedString is the sentinel of the ErrorUnterminatedString
+// errors, it wraps ErrUnexpectedEOF
+var ErrUnterminatedString = fmt.Errorf("unterminated string: %w", ErrUnexpectedEOF)
+
+// sentinels represents the sentinel errors mapped by the error codes
+var /* const */ sentinels = map[string]error{
+	ErrorInvalidNumber:         ErrInvalidNumber,
+	ErrorInvalidAnnotation:     ErrInvalidAnnotation,
+	ErrorUnsupportedAnnotation: ErrUnsupportedAnnotation,
+	ErrorUnterminatedString:    ErrUnterminatedString,
+}
+
+// SyntaxError represents an error found in the text along with the span
+// and position of the offending token.
+type SyntaxError struct {
+	Code    string
+	Message string
+	Start   int
+	End     int
+	Row     int
+	Col     int
+}
+
+// NewSyntaxError initializes the new SyntaxError for the specified token
+func NewSyntaxError(code string, message string, token *Token) *SyntaxError {
+	return &SyntaxError{
+		Code:    code,
+		Message: message,
+		Start:   token.Start,
+		End:     token.End,
+		Row:     token.Row,
+		Col:     token.Col,
+	}
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at %d:%d: %s", e.Code, e.Row, e.Col, e.Message)
+}
+
+// Unwrap returns the sentinel error of the error code, so that the callers
+// can check the error category using errors.Is, such as
+// errors.Is(err, ErrInvalidNumber).
+func (e *SyntaxError) Unwrap() error {
+	return sentinels[e.Code]
+}
+
+// Is returns true when the target is a SyntaxError with the same code
+func (e *SyntaxError) Is(target error) bool {
+	t, ok := target.(*SyntaxError)
+	return ok && t != nil && t.Code == e.Code
+}
+
+// Diagnostic returns the error as a Diagnostic with the error severity
+func (e *SyntaxError) Diagnostic() Diagnostic {
+	return Diagnostic{
+		Code:     e.Code,
+		Severity: SeverityError,
+		Message:  e.Message,
+		Start:    e.Start,
+		End:      e.End,
+		Row:      e.Row,
+		Col:      e.Col,
+		Fix:      fixes[e.Code],
+	}
+}
@@ -0,0 +1,42 @@
+package parsers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	tests := []struct {
+		text     string
+		sentinel error
+	}{
+		{"--5", ErrInvalidNumber},
+		{`du"1x"`, ErrInvalidAnnotation},
+		{`geo"1"`, ErrUnsupportedAnnotation},
+		{`"abc`, ErrUnterminatedString},
+		{`"abc`, ErrUnexpectedEOF},
+	}
+
+	for _, test := range tests {
+		err := NewLexer(test.text).ReadAll()
+		if !errors.Is(err, test.sentinel) {
+			t.Errorf("%s: expected %v to be %v", test.text, err, test.sentinel)
+		}
+
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%s: expected a SyntaxError, found %v", test.text, err)
+		}
+	}
+
+	err := NewLexer("1e").ReadAll()
+	if !errors.Is(err, &SyntaxError{Code: ErrorInvalidNumber}) {
+		t.Error("expected the errors with the same code to match")
+	}
+	if errors.Is(err, ErrUnexpectedEOF) || errors.Is(err, &SyntaxError{Code: ErrorUnterminatedString}) {
+		t.Error("expected the errors with other codes not to match")
+	}
+	if errors.Is(err, (*SyntaxError)(nil)) {
+		t.Error("expected the nil SyntaxError not to match")
+	}
+}
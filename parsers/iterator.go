@@ -0,0 +1,67 @@
+package parsers
+
+// TokenIterator iterates over the tokens of a text reading one token per
+// Next call. The white spaces are skipped and so are the comments unless
+// the underlying lexer has KeepComments set.
+//
+// The kinds of the tokens (Token.Type) are the stable Type constants:
+// TypeSeparator, TypeDatasep, TypeString, TypeRawString, TypeNumber,
+// TypeBool, TypeNull, TypeComment, TypeDuration, TypeUUID, TypeAnnotation
+// and TypeUnknownAnnotation.
+//
+//	it := TokenIter(input)
+//	for it.Next() {
+//		token := it.Token()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TokenIterator struct {
+	lexer *lexer
+	token *Token
+	err   error
+}
+
+// TokenIter returns the TokenIterator over the tokens of the input
+func TokenIter(input string) *TokenIterator {
+	return NewLexer(input).Iter()
+}
+
+// Iter returns the TokenIterator reading the tokens from the lexer, which
+// allows iterating with the lexer modes such as KeepComments.
+func (l *lexer) Iter() *TokenIterator {
+	return &TokenIterator{lexer: l}
+}
+
+// Next reads the next token, it returns false when there are no more
+// tokens or an error occurred.
+func (it *TokenIterator) Next() bool {
+	it.token = nil
+	if it.err != nil {
+		return false
+	}
+
+	for !it.lexer.done {
+		token, err := it.lexer.Read()
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if token != nil {
+			it.token = token
+			return true
+		}
+	}
+	return false
+}
+
+// Token returns the token read by the last Next call
+func (it *TokenIterator) Token() *Token {
+	return it.token
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *TokenIterator) Err() error {
+	return it.err
+}
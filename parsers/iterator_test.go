@@ -0,0 +1,59 @@
+package parsers
+
+import "testing"
+
+func TestTokenIter(t *testing.T) {
+	it := TokenIter("a, 'b' # c\n---\n{d: T}")
+
+	expected := []string{TypeString, TypeSeparator, TypeRawString, TypeDatasep,
+		TypeSeparator, TypeString, TypeSeparator, TypeBool, TypeSeparator}
+	kinds := make([]string, 0)
+	for it.Next() {
+		kinds = append(kinds, it.Token().Type)
+	}
+
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if len(kinds) != len(expected) {
+		t.Fatalf("expected %v, found %v", expected, kinds)
+	}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Errorf("token %d: expected %s, found %s", i, expected[i], kinds[i])
+		}
+	}
+
+	if it.Next() || it.Token() != nil {
+		t.Error("expected the iteration to stay finished")
+	}
+}
+
+func TestTokenIterOptions(t *testing.T) {
+	l := NewLexer("a # c")
+	l.KeepComments = true
+
+	it := l.Iter()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("expected 2 tokens, found %d", count)
+	}
+}
+
+func TestTokenIterError(t *testing.T) {
+	it := TokenIter("a, 1e, b")
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 || it.Err() == nil {
+		t.Errorf("expected 2 tokens and an error, found %d and %v", count, it.Err())
+	}
+	if it.Next() {
+		t.Error("expected the iteration to stop after the error")
+	}
+}
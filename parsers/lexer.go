@@ -4,6 +4,8 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type scanner func(l *lexer, start, end int) (bool, error)
@@ -17,6 +19,34 @@ type lexer struct {
 	tokens []*Token
 	done   bool
 
+	diagnostics []Diagnostic
+
+	// DeferNumbers skips the conversion of numbers to their values. The
+	// number tokens are still classified (see Token.Flags) but keep the raw
+	// text as the value, which suits the consumers needing only the syntax
+	// such as formatters and highlighters.
+	DeferNumbers bool
+
+	// KeepComments emits the # line comments as the comment tokens instead
+	// of skipping them.
+	KeepComments bool
+
+	// LenientAnnotations keeps the annotated strings with unsupported
+	// annotations as UnknownAnnotation values instead of failing, so that
+	// the documents using newer annotations can still be read.
+	LenientAnnotations bool
+
+	// NoShorthandLiterals reads the T, F and N shorthand literals as
+	// strings, for the data where they are legitimate string values.
+	NoShorthandLiterals bool
+
+	// CaseInsensitiveLiterals accepts the literals in any case such as
+	// True and NULL. The shorthand literals remain uppercase.
+	CaseInsensitiveLiterals bool
+
+	// NoLiterals reads all the boolean and null literals as strings
+	NoLiterals bool
+
 	// Current pos
 	ch    rune
 	index int
@@ -31,7 +61,7 @@ func NewLexer(text string) *lexer {
 	l := new(lexer)
 
 	l.text = []rune(text)
-	l.length = len(text)
+	l.length = len(l.text)
 	l.tokens = make([]*Token, 0)
 	l.done = false
 
@@ -87,20 +117,45 @@ func (l *lexer) Read() (*Token, error) {
 		token, err = l.scan(TypeString, stringScanner, true)
 		advance = 1
 	} else if l.ch == Quote {
-		token, err = l.scan("raw-string", rawStringScanner, true)
+		token, err = l.scan(TypeRawString, rawStringScanner, true)
 		advance = 1
+	} else if l.ch == Hash {
+		token, err = l.scan(TypeComment, commentScanner, false)
+		if !l.KeepComments {
+			token = nil
+		}
 	} else if datasep {
 		token = getToken(l, TypeDatasep, l.index, l.index+2)
 		advance = 3
+	} else if name := annotationName(l); name != "" {
+		token, err = l.scanAnnotated(name)
+		advance = 1
 	} else {
 		token, err = l.scan(TypeString, sepScanner, false)
-		makeSenseOfIt(token)
+		if err == nil && token != nil {
+			err = makeSenseOfIt(l, token)
+		}
 	}
 
 	if err != nil {
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			l.diagnostics = append(l.diagnostics, syntaxErr.Diagnostic())
+		}
 		return nil, err
 	}
 
+	if token != nil && token.Type == TypeUnknownAnnotation {
+		l.diagnostics = append(l.diagnostics, Diagnostic{
+			Code:     ErrorUnsupportedAnnotation,
+			Severity: SeverityWarning,
+			Message:  "unsupported annotation kept as is",
+			Start:    token.Start,
+			End:      token.End,
+			Row:      token.Row,
+			Col:      token.Col,
+		})
+	}
+
 	if advance != 0 {
 		l.advance(advance)
 	}
@@ -112,18 +167,26 @@ func (l *lexer) Read() (*Token, error) {
 	return token, err
 }
 
+/**
+ * GetDiagnostics returns the diagnostics reported while reading the tokens.
+ */
+func (l *lexer) GetDiagnostics() []Diagnostic {
+	return l.diagnostics
+}
+
 func (l *lexer) advance(times int) bool {
 
 	if l.index+1 < l.length {
+		// The char following the new line starts the next row
+		if l.ch == NewLine {
+			l.col = 0
+			l.row++
+		}
+
 		l.index++
 		l.col++
 		l.ch = l.text[l.index]
 
-		if l.ch == NewLine {
-			l.col = 1
-			l.row = 1
-		}
-
 		advanced := 1
 		result := true
 		for advanced < times {
@@ -141,6 +204,7 @@ func (l *lexer) advance(times int) bool {
 
 func (l *lexer) scan(tokenType string, scanner scanner, confined bool) (*Token, error) {
 	start := -1
+	row, col := l.row, l.col
 
 	if !isWS(l.ch) {
 		start = l.index
@@ -149,6 +213,7 @@ func (l *lexer) scan(tokenType string, scanner scanner, confined bool) (*Token,
 	for l.advance(1) {
 		if start == -1 && !isWS(l.ch) {
 			start = l.index
+			row, col = l.row, l.col
 		}
 
 		// Reached the end of the text, break it
@@ -173,14 +238,52 @@ func (l *lexer) scan(tokenType string, scanner scanner, confined bool) (*Token,
 	if confined || l.done {
 		end++
 	}
-	token := strings.TrimSpace(string(l.text[start:end]))
-	tokenLen := len(token)
+	token := strings.TrimFunc(string(l.text[start:end]), isWS)
+	tokenLen := utf8.RuneCountInString(token)
 
 	if tokenLen == 0 {
 		return nil, nil
 	}
 
-	return NewToken(token, token, tokenType, start, start+tokenLen-1, l.row, l.col), nil
+	// The confined strings must end with their closing quote, which is not
+	// the case when the text ends right after the opening quote or with an
+	// escaped quote.
+	if confined && !isCompleteString(tokenType, token) {
+		return nil, l.errorAt(ErrorUnterminatedString, "unterminated string", start)
+	}
+
+	return NewToken(token, token, tokenType, start, start+tokenLen-1, row, col), nil
+}
+
+// errorAt returns the SyntaxError spanning from the specified start index
+// to the current char.
+func (l *lexer) errorAt(code string, message string, start int) *SyntaxError {
+	row, col := 1, 1
+	for _, ch := range l.text[:start] {
+		col++
+		if ch == NewLine {
+			row++
+			col = 1
+		}
+	}
+
+	return &SyntaxError{
+		Code:    code,
+		Message: message,
+		Start:   start,
+		End:     l.index,
+		Row:     row,
+		Col:     col,
+	}
+}
+
+// isCompleteString returns true when the specified text of a confined
+// token is enclosed in its quotes.
+func isCompleteString(tokenType string, text string) bool {
+	if tokenType == TypeRawString {
+		return ReRawString.MatchString(text)
+	}
+	return ReRegularString.MatchString(text)
 }
 
 func getToken(l *lexer, tokenType string, start, end int) *Token {
@@ -195,6 +298,10 @@ func wsScanner(l *lexer, start, end int) (bool, error) {
 	return isWS(l.ch), nil
 }
 
+func commentScanner(l *lexer, start, end int) (bool, error) {
+	return !isEndOfLine(l.ch), nil
+}
+
 func sepScanner(l *lexer, start, end int) (bool, error) {
 	if isSeparator(l.ch) {
 		return false, nil
@@ -214,26 +321,19 @@ func rawStringScanner(l *lexer, start, end int) (bool, error) {
 
 	if l.ch != Quote {
 		if l.index == l.length-1 {
-			// incompelte-string
-			return false, errors.New("syntax-error")
+			return false, l.errorAt(ErrorUnterminatedString, "unterminated raw string", start)
 		}
 		return true, nil
 	}
 
-	nextCh, e := getNexCh(l)
-
-	// The current quote is a last char, stop scan.
-	if e != nil {
-		return false, nil
-	}
-
-	// The nextCh is a quote. It is escaping, cotinue scan.
-	if nextCh == Quote {
+	// The quotes are escaped by doubling them, so continue the scan till the
+	// text is a complete raw string which is not followed by another quote.
+	if !ReRawString.MatchString(string(l.text[start : l.index+1])) {
 		return true, nil
 	}
 
-	text := string(l.text[l.index : l.index+1])
-	return ReRawString.MatchString(text), nil
+	nextCh, e := getNexCh(l)
+	return e == nil && nextCh == Quote, nil
 }
 
 func stringScanner(l *lexer, start, end int) (bool, error) {
@@ -242,37 +342,80 @@ func stringScanner(l *lexer, start, end int) (bool, error) {
 
 	if l.ch != DoubleQuote {
 		if l.index == l.length-1 {
-			err = errors.New("syntax-error")
+			err = l.errorAt(ErrorUnterminatedString, "unterminated string", start)
 		}
 		return true, err
 	}
-	return ReRegularString.MatchString(string(l.text[start : l.index+1])), err
+	// Stop the scan once the closing quote completes the string
+	return !ReRegularString.MatchString(string(l.text[start : l.index+1])), err
 }
 
-func makeSenseOfIt(token *Token) {
+func makeSenseOfIt(l *lexer, token *Token) error {
 	text := token.Text
-	if text == "T" || text == "true" {
-		token.Val = true
-		token.Type = TypeBool
+	if val, tokenType, ok := literalOf(l, text); ok {
+		token.Val = val
+		token.Type = tokenType
+		return nil
+	}
+
+	if ReNumber.MatchString(text) {
+		if l.DeferNumbers {
+			token.Type = TypeNumber
+			token.Flags = ClassifyNumber(text)
+			return nil
+		}
+
+		// The numbers out of the float64 range keep their text as the value
+		// like in the DeferNumbers mode, NumberValue reports the range error.
+		token.Type = TypeNumber
+		token.Flags = ClassifyNumber(text)
+		if val, e := strconv.ParseFloat(text, 64); e == nil {
+			token.Val = val
+		}
+		return nil
 	}
 
-	if text == "F" || text == "false" {
-		token.Val = false
-		token.Type = TypeBool
+	if ReMalformedNumber.MatchString(text) && !ReZeroPadded.MatchString(text) {
+		return NewSyntaxError(ErrorInvalidNumber,
+			"malformed number "+strconv.Quote(text), token)
 	}
+	return nil
+}
 
-	if text == "N" || text == "null" {
-		token.Val = nil
-		token.Type = TypeNull
+// literalOf returns the value and type of the boolean and null literals
+// as per the literal modes of the lexer.
+func literalOf(l *lexer, text string) (interface{}, string, bool) {
+	if l.NoLiterals {
+		return nil, "", false
 	}
 
-	if ReNumber.MatchString(text) {
-		val, e := strconv.ParseFloat(text, 64)
-		if e == nil {
-			token.Val = val
-			token.Type = TypeNumber
+	if len(text) == 1 {
+		if l.NoShorthandLiterals {
+			return nil, "", false
 		}
+		switch text {
+		case "T":
+			return true, TypeBool, true
+		case "F":
+			return false, TypeBool, true
+		case "N":
+			return nil, TypeNull, true
+		}
+		return nil, "", false
 	}
+
+	if l.CaseInsensitiveLiterals {
+		text = strings.ToLower(text)
+	}
+	switch text {
+	case "true":
+		return true, TypeBool, true
+	case "false":
+		return false, TypeBool, true
+	case "null":
+		return nil, TypeNull, true
+	}
+	return nil, "", false
 }
 
 func getNexCh(l *lexer) (rune, error) {
@@ -297,8 +440,14 @@ func isSeparator(r rune) bool {
 	return strings.ContainsRune(Separators, r)
 }
 
+// isWS returns true for the control chars, the space and the Unicode white
+// spaces such as NEL, NBSP, U+2000 to U+200A, the line and paragraph
+// separators U+2028 and U+2029, and the BOM U+FEFF.
 func isWS(r rune) bool {
-	return r <= Space
+	if r <= Space {
+		return true
+	}
+	return unicode.IsSpace(r) || r == BOM
 }
 
 func isEndOfLine(r rune) bool {
@@ -19,3 +19,178 @@ func TestLexer(t *testing.T) {
 		utils.PrettyPrint(l.tokens)
 	}
 }
+
+func TestLexerPositions(t *testing.T) {
+	l := NewLexer("a, bc\n  {d: 1}")
+	e := l.ReadAll()
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	expected := []struct {
+		text     string
+		row, col int
+	}{
+		{"a", 1, 1}, {",", 1, 2}, {"bc", 1, 4},
+		{"{", 2, 3}, {"d", 2, 4}, {":", 2, 5}, {"1", 2, 7}, {"}", 2, 8},
+	}
+
+	if len(l.tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, found %d", len(expected), len(l.tokens))
+	}
+
+	for i, exp := range expected {
+		token := l.tokens[i]
+		if token.Text != exp.text || token.Row != exp.row || token.Col != exp.col {
+			t.Errorf("token %d: expected %q at %d:%d, found %q at %d:%d",
+				i, exp.text, exp.row, exp.col, token.Text, token.Row, token.Col)
+		}
+	}
+}
+
+func TestLexerComments(t *testing.T) {
+	text := "# header comment\na, b # trailing\n# last"
+
+	l := NewLexer(text)
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+	if len(l.tokens) != 3 || l.tokens[2].Text != "b" {
+		t.Errorf("expected comments to be skipped, found %d tokens", len(l.tokens))
+	}
+
+	l = NewLexer(text)
+	l.KeepComments = true
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	comments := make([]*Token, 0)
+	for _, token := range l.tokens {
+		if token.Type == TypeComment {
+			comments = append(comments, token)
+		}
+	}
+
+	expected := []string{"# header comment", "# trailing", "# last"}
+	if len(comments) != len(expected) {
+		t.Fatalf("expected %d comments, found %d", len(expected), len(comments))
+	}
+	for i, text := range expected {
+		if comments[i].Text != text {
+			t.Errorf("expected comment %q, found %q", text, comments[i].Text)
+		}
+	}
+	if comments[1].Row != 2 || comments[1].Col != 6 {
+		t.Errorf("unexpected comment position %d:%d", comments[1].Row, comments[1].Col)
+	}
+}
+
+func TestLexerStrings(t *testing.T) {
+	l := NewLexer(`"a", b, "c \"d\"", "e,f"`)
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	expected := []string{`"a"`, ",", "b", ",", `"c \"d\""`, ",", `"e,f"`}
+	if len(l.tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, found %d", len(expected), len(l.tokens))
+	}
+	for i, text := range expected {
+		if l.tokens[i].Text != text {
+			t.Errorf("token %d: expected %s, found %s", i, text, l.tokens[i].Text)
+		}
+	}
+}
+
+func TestLexerUnicodeWS(t *testing.T) {
+	l := NewLexer("\ufeff a,\u00a0b\u2003,\u3000\"\u00e9\"\u2028, c d\u0085")
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	expected := []string{"a", ",", "b", ",", "\"\u00e9\"", ",", "c d"}
+	if len(l.tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, found %d", len(expected), len(l.tokens))
+	}
+	for i, text := range expected {
+		if l.tokens[i].Text != text {
+			t.Errorf("token %d: expected %q, found %q", i, text, l.tokens[i].Text)
+		}
+	}
+
+	// The trailing BOM is trimmed like the other white spaces
+	l = NewLexer("a\ufeff, b\ufeff")
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+	if len(l.tokens) != 3 || l.tokens[0].Text != "a" || l.tokens[0].End != 0 || l.tokens[2].Text != "b" {
+		t.Errorf("unexpected tokens %+v", l.tokens)
+	}
+
+	// The lone white spaces must not produce tokens
+	for _, text := range []string{"\u0085", "a, \u0085", "\u0085\u00a0"} {
+		l = NewLexer(text)
+		if e := l.ReadAll(); e != nil {
+			t.Errorf("%q: unexpected error %v", text, e)
+		}
+	}
+
+	// The zero width space is not a white space
+	if isWS('\u200B') {
+		t.Error("expected U+200B not to be a white space")
+	}
+}
+
+func TestLexerUnicodeSpans(t *testing.T) {
+	l := NewLexer("\"\U0001F600é\", b")
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	if l.tokens[0].Start != 0 || l.tokens[0].End != 3 || l.tokens[2].Start != 6 {
+		t.Errorf("unexpected spans %+v, %+v", l.tokens[0], l.tokens[2])
+	}
+}
+
+func TestLexerLiterals(t *testing.T) {
+	text := "T, F, N, true, false, null, True, NULL, t"
+
+	tests := []struct {
+		configure func(l *lexer)
+		expected  []string
+	}{
+		{func(l *lexer) {}, []string{TypeBool, TypeBool, TypeNull, TypeBool, TypeBool, TypeNull,
+			TypeString, TypeString, TypeString}},
+		{func(l *lexer) { l.NoShorthandLiterals = true }, []string{TypeString, TypeString, TypeString,
+			TypeBool, TypeBool, TypeNull, TypeString, TypeString, TypeString}},
+		{func(l *lexer) { l.CaseInsensitiveLiterals = true }, []string{TypeBool, TypeBool, TypeNull,
+			TypeBool, TypeBool, TypeNull, TypeBool, TypeNull, TypeString}},
+		{func(l *lexer) { l.NoLiterals = true }, []string{TypeString, TypeString, TypeString,
+			TypeString, TypeString, TypeString, TypeString, TypeString, TypeString}},
+	}
+
+	for i, test := range tests {
+		l := NewLexer(text)
+		test.configure(l)
+		if e := l.ReadAll(); e != nil {
+			t.Fatal(e)
+		}
+
+		types := make([]string, 0)
+		for _, token := range l.tokens {
+			if token.Type != TypeSeparator {
+				types = append(types, token.Type)
+			}
+		}
+		if len(types) != len(test.expected) {
+			t.Fatalf("case %d: expected %v, found %v", i, test.expected, types)
+		}
+		for j := range types {
+			if types[j] != test.expected[j] {
+				t.Errorf("case %d: expected %v, found %v", i, test.expected, types)
+				break
+			}
+		}
+	}
+}
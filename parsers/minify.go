@@ -0,0 +1,251 @@
+package parsers
+
+import (
+	"errors"
+	"strings"
+)
+
+// Minify returns the smallest equivalent of the specified document. The
+// comments and insignificant white spaces are stripped, the literals are
+// shortened (true to T, false to F and null to N), the explicit + sign of
+// the numbers is dropped, the quoted strings which read back the same are
+// converted to open strings, and the optional braces around the top level
+// object of each section are removed. The result is lexed again and
+// compared with the document, an error is returned when they differ.
+func Minify(src string) (string, error) {
+	l := NewLexer(src)
+	l.LenientAnnotations = true
+	if err := l.ReadAll(); err != nil {
+		return "", err
+	}
+
+	tokens := dropOptionalBraces(l.tokens)
+
+	result := joinTokens(tokens, true)
+	if !readsBackAs(result, tokens) {
+		// Keep all the quoted strings as they are
+		result = joinTokens(tokens, false)
+		if !readsBackAs(result, tokens) {
+			return "", errors.New("minify: the minified document is not equivalent")
+		}
+	}
+
+	return result, nil
+}
+
+// joinTokens writes the shortest text of the tokens. The quoted strings are
+// converted to open strings only when unquote is true.
+func joinTokens(tokens []*Token, unquote bool) string {
+	var b strings.Builder
+	for i, token := range tokens {
+		var prev, next *Token
+		if i > 0 {
+			prev = tokens[i-1]
+		}
+		if i < len(tokens)-1 {
+			next = tokens[i+1]
+		}
+
+		if prev != nil {
+			if token.Type == TypeDatasep {
+				// Keep the section separator on its own line so that it
+				// doesn't merge with an open string ending with a hyphen.
+				b.WriteRune(NewLine)
+			} else if isValue(prev) && isValue(token) && !endsWithDoubleQuote(prev) {
+				// The open strings continue across the lines, only a comment
+				// or a quote ends them. An empty comment keeps the adjacent
+				// values apart.
+				b.WriteRune(Hash)
+				b.WriteRune(NewLine)
+			}
+		}
+
+		// A converted string must not touch the adjacent values
+		if unquote && !isValue(prev) && !isValue(next) {
+			b.WriteString(minifyToken(token))
+		} else {
+			b.WriteString(shortenToken(token))
+		}
+	}
+	return b.String()
+}
+
+// isValue returns true when the token is neither missing, nor a separator
+// nor a section separator.
+func isValue(token *Token) bool {
+	return token != nil && token.Type != TypeSeparator && token.Type != TypeDatasep
+}
+
+// endsWithDoubleQuote returns true for the double quoted (and annotated)
+// strings which end with their closing quote and hence can't be extended.
+// The open strings may end with a double quote too (5").
+func endsWithDoubleQuote(token *Token) bool {
+	switch token.Type {
+	case TypeString:
+		return len(token.Text) > 1 && token.Text[0] == DoubleQuote
+	case TypeDuration, TypeUUID, TypeAnnotation, TypeUnknownAnnotation:
+		return true
+	}
+	return false
+}
+
+// minifyToken returns the shortest text of the token
+func minifyToken(token *Token) string {
+	if token.Type == TypeString || token.Type == TypeRawString {
+		text := token.Text
+		if len(text) >= 2 && (text[0] == DoubleQuote || text[0] == Quote) {
+			content := text[1 : len(text)-1]
+			if isSafeOpenString(content) {
+				return content
+			}
+		}
+	}
+	return shortenToken(token)
+}
+
+// shortenToken returns the shortest text of the token without changing its
+// quotes.
+func shortenToken(token *Token) string {
+	switch token.Type {
+	case TypeBool:
+		if token.Val == true {
+			return "T"
+		}
+		return "F"
+
+	case TypeNull:
+		return "N"
+
+	case TypeNumber:
+		return strings.TrimPrefix(token.Text, "+")
+	}
+	return token.Text
+}
+
+// isSafeOpenString returns true when the specified string may be written
+// as an open string. The strings starting with $ (schema references), ~
+// (collection rows) or @ keep their quotes as they mean something else
+// when not quoted.
+func isSafeOpenString(content string) bool {
+	if content == "" || strings.ContainsAny(content, "\\\"'\r\n") ||
+		strings.ContainsAny(content[:1], "$~@") {
+		return false
+	}
+
+	l := NewLexer(content)
+	if err := l.ReadAll(); err != nil {
+		return false
+	}
+	return len(l.tokens) == 1 && l.tokens[0].Type == TypeString && l.tokens[0].Text == content
+}
+
+// readsBackAs returns true when the text is lexed into the tokens
+// equivalent to the specified ones.
+func readsBackAs(text string, tokens []*Token) bool {
+	l := NewLexer(text)
+	l.LenientAnnotations = true
+	if err := l.ReadAll(); err != nil || len(l.tokens) != len(tokens) {
+		return false
+	}
+
+	for i, token := range l.tokens {
+		if !equivalentTokens(token, tokens[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equivalentTokens returns true when both the tokens represent the same
+// value, the quoted and open strings holding the same text are equivalent.
+func equivalentTokens(a, b *Token) bool {
+	if isStringToken(a) || isStringToken(b) {
+		return isStringToken(a) && isStringToken(b) && unquoted(a) == unquoted(b)
+	}
+	if a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case TypeBool, TypeNull:
+		return a.Val == b.Val
+
+	case TypeNumber:
+		return strings.TrimPrefix(a.Text, "+") == strings.TrimPrefix(b.Text, "+") ||
+			ValueEqual(a.Val, b.Val)
+	}
+	return a.Text == b.Text
+}
+
+func isStringToken(token *Token) bool {
+	return token.Type == TypeString || token.Type == TypeRawString
+}
+
+// unquoted returns the content of the string token as written, the escape
+// sequences are left as they are.
+func unquoted(token *Token) string {
+	text := token.Text
+	if len(text) < 2 {
+		return text
+	}
+	switch text[0] {
+	case DoubleQuote:
+		return text[1 : len(text)-1]
+	case Quote:
+		return strings.Replace(text[1:len(text)-1], "''", "'", -1)
+	}
+	return text
+}
+
+// dropOptionalBraces removes the braces enclosing the whole top level
+// object of each section.
+func dropOptionalBraces(tokens []*Token) []*Token {
+	result := make([]*Token, 0, len(tokens))
+
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && tokens[i].Type != TypeDatasep {
+			continue
+		}
+
+		section := tokens[start:i]
+		if enclosedByBraces(section) {
+			section = section[1 : len(section)-1]
+		}
+		result = append(result, section...)
+
+		if i < len(tokens) {
+			result = append(result, tokens[i])
+		}
+		start = i + 1
+	}
+
+	return result
+}
+
+// enclosedByBraces returns true when the first token of the section opens
+// a non empty object which is closed by the last token.
+func enclosedByBraces(section []*Token) bool {
+	if len(section) < 3 || !isSep(section[0], OpenCurly) ||
+		!isSep(section[len(section)-1], CloseCurly) {
+		return false
+	}
+
+	// The leading name of a section (--- name: {...}) is not an object brace
+	depth := 0
+	for i, token := range section {
+		if isSep(token, OpenCurly) || isSep(token, OpenSquare) {
+			depth++
+		} else if isSep(token, CloseCurly) || isSep(token, CloseSquare) {
+			depth--
+			if depth == 0 && i < len(section)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func isSep(token *Token, ch rune) bool {
+	return token.Type == TypeSeparator && token.Text == string(ch)
+}
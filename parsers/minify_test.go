@@ -0,0 +1,89 @@
+package parsers
+
+import "testing"
+
+func TestMinify(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{"{ name: \"John\", active: true, manager: null } # comment", "name:John,active:T,manager:N"},
+		{"a, b\n---\n{x: 1}, {y: 2}", "a,b\n---{x:1},{y:2}"},
+		{"{a: [1, 2]}, {b: 3}", "{a:[1,2]},{b:3}"},
+		{"{}", "{}"},
+		{"[+1, -2.5, 1e3]", "[1,-2.5,1e3]"},
+		{"x-\n--- y", "x-\n---y"},
+		{`{"John Doe", 'raw', " pad", "a,b", "25", "T", "a\"b", '', du"1h", geo"x"}`,
+			`John Doe,raw," pad","a,b","25","T","a\"b",'',du"1h",geo"x"`},
+		{"name: \"x\" # c\nage: 1", `name:"x"age:1`},
+		{"1 # c\n2", "1#\n2"},
+		{`"a" "b"`, `"a""b"`},
+		{`du"1h""x"du"1h"`, `du"1h""x"du"1h"`},
+		{"'a' # c\n'b'", "'a'#\n'b'"},
+		{"5\" # c\nx", "5\"#\nx"},
+		{"{a: \"$user\"}", "a:\"$user\""},
+		{"\"~\", b", "\"~\",b"},
+		{"\"~x\", \"@x\", \"a$\"", "\"~x\",\"@x\",a$"},
+	}
+
+	for _, test := range tests {
+		result, err := Minify(test.src)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.src, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("Minify(%q) = %q, expected %q", test.src, result, test.expected)
+		}
+	}
+
+	if _, err := Minify("a, 1e"); err == nil {
+		t.Error("expected an error for the invalid document")
+	}
+}
+
+func TestMinifyRoundTrip(t *testing.T) {
+	tests := []string{
+		"# people\n{\n  name: \"Jane\",\n  age: +25,\n  tags: [ \"a b\", 'c' ]\n}\n---\n  x, y  # trailing\n",
+		"name: \"x\" # c\nage: 1",
+		"1 # c\n2",
+		"true # c\nnull # c\n\"n\"",
+		`"a" "b"`,
+		`du"1h""x"du"1h"`,
+		"'a' # c\n'b' # c\n\"c\"",
+		"a # c\n\"b\"\n--- c-",
+		"5\" # c\nx",
+		"5\" # c\n\"b\" # c\n'c'",
+	}
+
+	tokens := func(text string) []*Token {
+		l := NewLexer(text)
+		l.LenientAnnotations = true
+		if e := l.ReadAll(); e != nil {
+			t.Fatal(e)
+		}
+		return l.tokens
+	}
+
+	for _, src := range tests {
+		minified, err := Minify(src)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", src, err)
+			continue
+		}
+
+		original, reread := dropOptionalBraces(tokens(src)), tokens(minified)
+		if len(original) != len(reread) {
+			t.Errorf("%q: %q is read as %d tokens, expected %d", src, minified, len(reread), len(original))
+			continue
+		}
+		for i := range original {
+			if original[i].Type != reread[i].Type && !(isStringToken(original[i]) && isStringToken(reread[i])) {
+				t.Errorf("%q: expected %s, found %s", src, original[i].Type, reread[i].Type)
+			}
+			if !equivalentTokens(original[i], reread[i]) {
+				t.Errorf("%q: expected %q, found %q", src, original[i].Text, reread[i].Text)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package parsers
+
+import (
+	"errors"
+	"strconv"
+)
+
+// NumberFlags represents the syntactic traits of a number token which are
+// recorded at scan-time without converting the number to its value.
+type NumberFlags int
+
+const (
+	// NumberNegative is set when the number starts with the - sign
+	NumberNegative NumberFlags = 1 << iota
+
+	// NumberPositive is set when the number starts with the explicit + sign
+	NumberPositive
+
+	// NumberFraction is set when the number has a fractional part
+	NumberFraction
+
+	// NumberExponent is set when the number has an exponent part
+	NumberExponent
+)
+
+// IsInteger returns true when the number has neither fraction nor exponent
+func (f NumberFlags) IsInteger() bool {
+	return f&(NumberFraction|NumberExponent) == 0
+}
+
+// ClassifyNumber returns the flags of the specified number text. The text
+// must already be a valid IO number (see ReNumber).
+func ClassifyNumber(text string) NumberFlags {
+	var flags NumberFlags
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '-':
+			if i == 0 {
+				flags |= NumberNegative
+			}
+		case '+':
+			if i == 0 {
+				flags |= NumberPositive
+			}
+		case '.':
+			flags |= NumberFraction
+		case 'e', 'E':
+			flags |= NumberExponent
+		}
+	}
+
+	return flags
+}
+
+// NumberValue converts the text of the number token to its value. It is
+// meant for the tokens read with the DeferNumbers mode, where the value
+// conversion is skipped at scan-time, and reports the range error of the
+// numbers out of the float64 range in both modes.
+func (t *Token) NumberValue() (float64, error) {
+	if t.Type != TypeNumber {
+		return 0, errors.New("not-a-number")
+	}
+
+	if val, ok := t.Val.(float64); ok {
+		return val, nil
+	}
+	return strconv.ParseFloat(t.Text, 64)
+}
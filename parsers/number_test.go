@@ -0,0 +1,171 @@
+package parsers
+
+import "testing"
+
+func TestClassifyNumber(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected NumberFlags
+	}{
+		{"0", 0},
+		{"-12", NumberNegative},
+		{"+1.5", NumberPositive | NumberFraction},
+		{"2.3e-10", NumberFraction | NumberExponent},
+		{"-1E5", NumberNegative | NumberExponent},
+	}
+
+	for _, test := range tests {
+		flags := ClassifyNumber(test.text)
+		if flags != test.expected {
+			t.Errorf("ClassifyNumber(%q) = %b, expected %b", test.text, flags, test.expected)
+		}
+		if flags.IsInteger() != (flags&(NumberFraction|NumberExponent) == 0) {
+			t.Errorf("IsInteger mismatch for %q", test.text)
+		}
+	}
+}
+
+func TestDeferNumbers(t *testing.T) {
+	l := NewLexer("1, -2.5, 2.3e+1000")
+	l.DeferNumbers = true
+	if e := l.ReadAll(); e != nil {
+		t.Fatal(e)
+	}
+
+	numbers := []*Token{l.tokens[0], l.tokens[2], l.tokens[4]}
+	for _, token := range numbers {
+		if token.Type != TypeNumber || token.Val != token.Text {
+			t.Errorf("expected deferred number token, found %+v", token)
+		}
+	}
+
+	if numbers[1].Flags != NumberNegative|NumberFraction {
+		t.Errorf("unexpected flags %b", numbers[1].Flags)
+	}
+
+	val, e := numbers[1].NumberValue()
+	if e != nil || val != -2.5 {
+		t.Errorf("expected -2.5, found %v (%v)", val, e)
+	}
+
+	// The out of range numbers fail only when the value is requested
+	if _, e := numbers[2].NumberValue(); e == nil {
+		t.Errorf("expected range error for %q", numbers[2].Text)
+	}
+}
+
+func TestPlusSign(t *testing.T) {
+	for _, deferred := range []bool{false, true} {
+		l := NewLexer("+42, +1.5e2")
+		l.DeferNumbers = deferred
+		if e := l.ReadAll(); e != nil {
+			t.Fatal(e)
+		}
+
+		// The lexeme keeps the sign while the value is canonical
+		expected := []float64{42, 150}
+		for i, token := range []*Token{l.tokens[0], l.tokens[2]} {
+			if token.Text[0] != '+' || token.Flags&NumberPositive == 0 {
+				t.Errorf("expected the + sign to be preserved, found %+v", token)
+			}
+
+			val, e := token.NumberValue()
+			if e != nil || val != expected[i] {
+				t.Errorf("expected %v, found %v (%v)", expected[i], val, e)
+			}
+		}
+	}
+}
+
+func TestMalformedNumber(t *testing.T) {
+	tests := []struct {
+		text            string
+		start, end, col int
+	}{
+		{"a, 1e", 3, 4, 4},
+		{"a, 2.5E+", 3, 7, 4},
+		{"0x", 0, 1, 1},
+		{"[--5]", 1, 3, 2},
+		{"+-1.5", 0, 4, 1},
+		{"1.", 0, 1, 1},
+		{".5", 0, 1, 1},
+		{"-.5", 0, 2, 1},
+		{"-01", 0, 2, 1},
+		{"01.5", 0, 3, 1},
+		{"1e5e", 0, 3, 1},
+		{"0x1F", 0, 3, 1},
+		{"0b101", 0, 4, 1},
+		{"0o", 0, 1, 1},
+	}
+
+	for _, test := range tests {
+		err := NewLexer(test.text).ReadAll()
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("%q: expected SyntaxError, found %v", test.text, err)
+			continue
+		}
+		if syntaxErr.Code != ErrorInvalidNumber || syntaxErr.Start != test.start ||
+			syntaxErr.End != test.end || syntaxErr.Row != 1 || syntaxErr.Col != test.col {
+			t.Errorf("%q: unexpected error %+v", test.text, syntaxErr)
+		}
+	}
+
+	// The zero padded digits such as the zip codes and the ids are open strings
+	for _, text := range []string{"02134", "007", "01", "00"} {
+		l := NewLexer(text)
+		if err := l.ReadAll(); err != nil {
+			t.Errorf("%q: unexpected error %v", text, err)
+			continue
+		}
+		if len(l.tokens) != 1 || l.tokens[0].Type != TypeString || l.tokens[0].Val != text {
+			t.Errorf("%q: expected an open string, found %+v", text, l.tokens)
+		}
+	}
+
+	// The strings which merely contain numbers remain open strings by design
+	for _, text := range []string{"1st", "2020-01-01", "1.2.3", "1..2", "e5", "-", ".", "x0", "1-2",
+		"Bob,0xford", "0bar", "0xg", "0o9", "0b102"} {
+		if err := NewLexer(text).ReadAll(); err != nil {
+			t.Errorf("%q: unexpected error %v", text, err)
+		}
+	}
+}
+
+func TestNumberModes(t *testing.T) {
+	text := "1, -2.5, 2.3e+1000, -1e-400"
+
+	types := func(deferred bool) []*Token {
+		l := NewLexer(text)
+		l.DeferNumbers = deferred
+		if e := l.ReadAll(); e != nil {
+			t.Fatal(e)
+		}
+		return l.tokens
+	}
+
+	regular, deferred := types(false), types(true)
+	if len(regular) != len(deferred) {
+		t.Fatalf("expected the same tokens, found %d and %d", len(regular), len(deferred))
+	}
+
+	for i := range regular {
+		a, b := regular[i], deferred[i]
+		if a.Type != b.Type || a.Flags != b.Flags {
+			t.Errorf("token %d: %s %b differs from the deferred %s %b", i, a.Type, a.Flags, b.Type, b.Flags)
+		}
+
+		if a.Type != TypeNumber {
+			continue
+		}
+		valA, errA := a.NumberValue()
+		valB, errB := b.NumberValue()
+		if valA != valB || (errA == nil) != (errB == nil) {
+			t.Errorf("token %d: %v (%v) differs from the deferred %v (%v)", i, valA, errA, valB, errB)
+		}
+	}
+
+	if _, e := regular[4].NumberValue(); e == nil {
+		t.Errorf("expected range error for %q", regular[4].Text)
+	}
+}
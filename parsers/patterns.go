@@ -25,3 +25,18 @@ var /* const */ ReNumber = regexp.MustCompile(`^([-+]?(?:0|[1-9]\d*)(?:\.\d+)?(?
 
 // ReCheckFloat checks the 
 var /* const */ ReCheckFloat = regexp.MustCompile(`[\.eE]`)
+
+// ReMalformedNumber ensures that the specified string looks like a number but
+// is not a valid one. A string looks like a number when it consists of the
+// signs, the digits with at most one dot and the exponents, or when it
+// starts with a radix prefix. That covers the exponents without digits
+// (1e) or repeated (1e5e), the leading and trailing dots (.5, 1.), the
+// leading zeros (-01, 01.5), the repeated signs (--5) and the radix prefixes
+// (0x, 0x1F, 0b101). The strings with several dots (1.2.3) or other chars
+// (2020-01-01, 1st, 0xford) don't look like numbers and remain open strings.
+var /* const */ ReMalformedNumber = regexp.MustCompile(`^(?:[-+]*(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d*)*|[-+]*(?:0[xX][0-9a-fA-F]*|0[oO][0-7]*|0[bB][01]*))$`)
+
+// ReZeroPadded ensures that the specified string consists of the digits
+// only with the leading zeros, such as the zip codes (02134) and the ids
+// (007). These remain open strings rather than the malformed numbers.
+var /* const */ ReZeroPadded = regexp.MustCompile(`^0\d+$`)
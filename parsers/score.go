@@ -0,0 +1,98 @@
+package parsers
+
+// ComplexityScore represents the structural metrics of a document gathered
+// by a single byte scan, without tokenizing or parsing the values.
+type ComplexityScore struct {
+	// Bytes is the total size of the input
+	Bytes int
+
+	// Depth is the maximum nesting depth of objects and arrays
+	Depth int
+
+	// Nodes is the estimated number of values in the document
+	Nodes int
+
+	// StringBytes is the number of bytes enclosed in quoted strings
+	StringBytes int
+
+	// Sections is the number of data sections separated by ---
+	Sections int
+}
+
+// Score scans the input once and returns its ComplexityScore so that the
+// pathological payloads can be rejected or deprioritized before parsing.
+func Score(input []byte) ComplexityScore {
+	score := ComplexityScore{Bytes: len(input)}
+	depth := 0
+	hasValue := false
+
+	for i := 0; i < len(input); i++ {
+		ch := input[i]
+
+		switch {
+		case ch == DoubleQuote || ch == Quote:
+			end := scoreSkipString(input, i)
+			score.StringBytes += end - i - 1
+			i = end
+			hasValue = true
+
+		case ch == Hash:
+			for i+1 < len(input) && input[i+1] != NewLine {
+				i++
+			}
+
+		case ch == OpenCurly || ch == OpenSquare:
+			depth++
+			if depth > score.Depth {
+				score.Depth = depth
+			}
+			score.Nodes++
+
+		case ch == CloseCurly || ch == CloseSquare:
+			if depth > 0 {
+				depth--
+			}
+
+		case ch == Comma:
+			score.Nodes++
+
+		case ch == Hyphen && i+2 < len(input) &&
+			input[i+1] == Hyphen && input[i+2] == Hyphen:
+			score.Sections++
+			score.Nodes++
+			i += 2
+
+		case ch > Space:
+			hasValue = true
+		}
+	}
+
+	if hasValue || score.Nodes > 0 {
+		score.Nodes++
+		score.Sections++
+	}
+
+	return score
+}
+
+// scoreSkipString returns the index of the quote closing the string which
+// starts at the specified index, or the input length when it is unterminated.
+func scoreSkipString(input []byte, start int) int {
+	quote := input[start]
+	for i := start + 1; i < len(input); i++ {
+		ch := input[i]
+		if ch == '\\' && quote == DoubleQuote {
+			i++
+			continue
+		}
+		if ch == quote {
+			// The raw strings escape the quote by doubling it
+			if quote == Quote && i+1 < len(input) && input[i+1] == Quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(input)
+}
@@ -0,0 +1,25 @@
+package parsers
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ComplexityScore
+	}{
+		{``, ComplexityScore{}},
+		{`a, b, c`, ComplexityScore{Bytes: 7, Nodes: 3, Sections: 1}},
+		{`{a: [1, {b: 2}]}`, ComplexityScore{Bytes: 16, Depth: 3, Nodes: 5, Sections: 1}},
+		{`"a{b", 'c''d'`, ComplexityScore{Bytes: 13, Nodes: 2, StringBytes: 7, Sections: 1}},
+		{"a\n---\nb, c", ComplexityScore{Bytes: 10, Nodes: 3, Sections: 2}},
+		{"a, # {b, c\nd", ComplexityScore{Bytes: 12, Nodes: 2, Sections: 1}},
+		{`"unterminated`, ComplexityScore{Bytes: 13, Nodes: 1, StringBytes: 12, Sections: 1}},
+	}
+
+	for _, test := range tests {
+		score := Score([]byte(test.input))
+		if score != test.expected {
+			t.Errorf("Score(%q) = %+v, expected %+v", test.input, score, test.expected)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// snippetContext represents the number of lines shown before the error line
+const snippetContext = 2
+
+// Pretty renders the error along with the offending line of the source,
+// a caret marking the error span and a couple of preceding context lines.
+//
+//	error[invalid-number]: malformed number "1e"
+//	 --> 2:4
+//	  |
+//	1 | a, b
+//	2 | c, 1e
+//	  |    ^^
+func (e *SyntaxError) Pretty(source string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "error[%s]: %s\n", e.Code, e.Message)
+
+	lines := strings.Split(source, "\n")
+	if e.Row < 1 || e.Row > len(lines) {
+		fmt.Fprintf(&b, " --> %d:%d\n", e.Row, e.Col)
+		return b.String()
+	}
+
+	first := e.Row - snippetContext
+	if first < 1 {
+		first = 1
+	}
+	width := len(strconv.Itoa(e.Row))
+	gutter := strings.Repeat(" ", width)
+
+	fmt.Fprintf(&b, "%s--> %d:%d\n", gutter, e.Row, e.Col)
+	fmt.Fprintf(&b, "%s |\n", gutter)
+	for row := first; row <= e.Row; row++ {
+		fmt.Fprintf(&b, "%*d | %s\n", width, row, strings.TrimRight(lines[row-1], "\r"))
+	}
+
+	// Indent the caret with the same tabs as the line so that it aligns
+	line := []rune(lines[e.Row-1])
+	indent := make([]rune, 0, e.Col)
+	for i := 0; i < e.Col-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			indent = append(indent, '\t')
+		} else {
+			indent = append(indent, ' ')
+		}
+	}
+
+	carets := e.End - e.Start + 1
+	if remaining := len(line) - (e.Col - 1); carets > remaining {
+		carets = remaining
+	}
+	if carets < 1 {
+		carets = 1
+	}
+
+	fmt.Fprintf(&b, "%s | %s%s\n", gutter, string(indent), strings.Repeat("^", carets))
+	return b.String()
+}
+
+// PrettyError renders the specified error with the source snippet when it
+// is or wraps a SyntaxError, otherwise it returns the error message as is.
+func PrettyError(err error, source string) string {
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Pretty(source)
+	}
+	return err.Error()
+}
@@ -0,0 +1,47 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPretty(t *testing.T) {
+	source := "a, b\nc, d\n\te, 1e, f"
+	err := NewLexer(source).ReadAll()
+
+	expected := "error[invalid-number]: malformed number \"1e\"\n" +
+		" --> 3:5\n" +
+		"  |\n" +
+		"1 | a, b\n" +
+		"2 | c, d\n" +
+		"3 | \te, 1e, f\n" +
+		"  | \t   ^^\n"
+
+	if pretty := PrettyError(err, source); pretty != expected {
+		t.Errorf("unexpected snippet\n%s\nexpected\n%s", pretty, expected)
+	}
+
+	// The spans running past the line are clipped
+	source = "x,\n\"abc\ndef"
+	err = NewLexer(source).ReadAll()
+	expected = "error[unterminated-string]: unterminated string\n" +
+		" --> 2:1\n" +
+		"  |\n" +
+		"1 | x,\n" +
+		"2 | \"abc\n" +
+		"  | ^^^^\n"
+
+	if pretty := PrettyError(err, source); pretty != expected {
+		t.Errorf("unexpected snippet\n%s\nexpected\n%s", pretty, expected)
+	}
+
+	// The wrapped errors are rendered with the snippet as well
+	if pretty := PrettyError(fmt.Errorf("load: %w", err), source); pretty != expected {
+		t.Errorf("unexpected snippet\n%s\nexpected\n%s", pretty, expected)
+	}
+
+	if pretty := PrettyError(errors.New("other"), source); pretty != "other" {
+		t.Errorf("unexpected message %q", pretty)
+	}
+}
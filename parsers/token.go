@@ -11,6 +11,9 @@ type Token struct {
 	End   int
 	Row   int
 	Col   int
+
+	// Flags holds the NumberFlags of the number tokens
+	Flags NumberFlags
 }
 
 /**
@@ -0,0 +1,86 @@
+package parsers
+
+import (
+	"runtime/debug"
+	"sort"
+)
+
+// modulePath represents the path of this module
+const modulePath = "github.com/maniartech/InternetObject-go"
+
+// version represents the version of the parser. It can be stamped at build
+// time using -ldflags "-X github.com/maniartech/InternetObject-go/parsers.version=v1.2.3"
+var version = ""
+
+// features represents the grammar features and backends compiled in, the
+// annotation:<name> features are derived from the annotations registry
+var /* const */ features = []string{
+	"lexer",
+	"comments",
+	"defer-numbers",
+	"embedded-blocks",
+	"lenient-annotations",
+	"custom-annotations",
+	"literal-options",
+	"token-iterator",
+}
+
+// Version returns the version of the parser. When it is not stamped at
+// build time, the version of the module recorded in the build info is
+// returned, or "devel" when it is not available.
+func Version() string {
+	if version != "" {
+		return version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if ok {
+		if info.Main.Path == modulePath {
+			return buildVersion(info.Main.Version)
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				return buildVersion(dep.Version)
+			}
+		}
+	}
+	return "devel"
+}
+
+// buildVersion maps the empty and the "(devel)" placeholder versions of the
+// build info to "devel"
+func buildVersion(v string) string {
+	if v == "" || v == "(devel)" {
+		return "devel"
+	}
+	return v
+}
+
+// Features returns the names of the grammar features and backends compiled
+// in, so that the applications and bug reports can record them. Each
+// supported annotation is reported as annotation:<name>, including the
+// ones registered using RegisterAnnotation.
+func Features() []string {
+	result := make([]string, len(features))
+	copy(result, features)
+
+	annotationsMutex.RLock()
+	names := make([]string, 0, len(annotations))
+	for name := range annotations {
+		names = append(names, "annotation:"+name)
+	}
+	annotationsMutex.RUnlock()
+
+	sort.Strings(names)
+	return append(result, names...)
+}
+
+// HasFeature returns true when the specified feature is compiled in
+func HasFeature(name string) bool {
+	for _, feature := range Features() {
+		if feature == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package parsers
+
+import "testing"
+
+func TestVersion(t *testing.T) {
+	if Version() == "" {
+		t.Error("expected a version")
+	}
+
+	if v := Version(); v == "(devel)" {
+		t.Errorf("expected the placeholder to be mapped, found %q", v)
+	}
+	if buildVersion("(devel)") != "devel" || buildVersion("") != "devel" ||
+		buildVersion("v0.1.0") != "v0.1.0" {
+		t.Error("unexpected build version mapping")
+	}
+
+	version = "v1.2.3"
+	defer func() { version = "" }()
+	if Version() != "v1.2.3" {
+		t.Errorf("expected the stamped version, found %q", Version())
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	for _, name := range []string{"comments", "token-iterator", "literal-options", "annotation:du", "annotation:u"} {
+		if !HasFeature(name) {
+			t.Errorf("expected %s in %v", name, Features())
+		}
+	}
+	if HasFeature("unknown") || HasFeature("annotation:feat") {
+		t.Errorf("unexpected features %v", Features())
+	}
+
+	identity := func(content string) (interface{}, error) { return content, nil }
+	format := func(v interface{}) (string, error) { return v.(string), nil }
+	if err := RegisterAnnotation("feat", identity, format); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		annotationsMutex.Lock()
+		delete(annotations, "feat")
+		annotationsMutex.Unlock()
+	}()
+	if !HasFeature("annotation:feat") {
+		t.Error("expected the registered annotation to be reported")
+	}
+
+	// The returned features must not alias the package list
+	list := Features()
+	list[0] = "changed"
+	if features[0] == "changed" {
+		t.Error("expected Features to return a copy")
+	}
+}
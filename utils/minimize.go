@@ -0,0 +1,78 @@
+package utils
+
+import "strings"
+
+// Minimize reduces the input to a smallest reproducer for which the fails
+// function still returns true, using delta debugging first over the lines
+// and then over the characters of the input. The fails function must be
+// deterministic and should check for the specific failure signature (such
+// as the error code and message) rather than any failure. When the input
+// itself does not fail, it is returned unchanged.
+func Minimize(input string, fails func(input string) bool) string {
+	if !fails(input) {
+		return input
+	}
+
+	test := func(units []string) bool {
+		return fails(strings.Join(units, ""))
+	}
+
+	lines := ddmin(strings.SplitAfter(input, "\n"), test)
+	chars := ddmin(strings.Split(strings.Join(lines, ""), ""), test)
+	return strings.Join(chars, "")
+}
+
+// ddmin implements the delta debugging minimization of the failing units,
+// returning the units from which no single chunk can be removed.
+func ddmin(units []string, test func(units []string) bool) []string {
+	n := 2
+
+	for len(units) >= 2 {
+		chunk := (len(units) + n - 1) / n
+		reduced := false
+
+		// Try each chunk alone, then each complement of a chunk
+		for i := 0; i < len(units) && !reduced; i += chunk {
+			subset := units[i:minInt(i+chunk, len(units))]
+			if test(subset) {
+				units = subset
+				n = 2
+				reduced = true
+			}
+		}
+
+		for i := 0; i < len(units) && !reduced; i += chunk {
+			complement := make([]string, 0, len(units))
+			complement = append(complement, units[:i]...)
+			complement = append(complement, units[minInt(i+chunk, len(units)):]...)
+			if test(complement) {
+				units = complement
+				n = maxInt(n-1, 2)
+				reduced = true
+			}
+		}
+
+		if !reduced {
+			if n >= len(units) {
+				break
+			}
+			n = minInt(n*2, len(units))
+		}
+	}
+
+	return units
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMinimize(t *testing.T) {
+	input := "name, age\n---\nJohn, 25\n\"Jane, 30\nJim, 40\n"
+
+	// The failure reproduces as long as an unterminated quote remains
+	fails := func(input string) bool {
+		return strings.Count(input, `"`)%2 == 1
+	}
+
+	if result := Minimize(input, fails); result != `"` {
+		t.Errorf("expected the lone quote, found %q", result)
+	}
+
+	// The input which doesn't fail is returned as is
+	if result := Minimize("a, b", fails); result != "a, b" {
+		t.Errorf("expected the input unchanged, found %q", result)
+	}
+
+	// The failures needing several parts keep all of them
+	fails = func(input string) bool {
+		return strings.Contains(input, "{") && strings.Contains(input, "2.5e")
+	}
+	if result := Minimize("a: {b: 1, c: 2.5e}\n", fails); result != "{2.5e" {
+		t.Errorf("expected {2.5e, found %q", result)
+	}
+}